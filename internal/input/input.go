@@ -0,0 +1,34 @@
+// Package input defines the backend-agnostic events Scene's event loop runs
+// on, so the SDL2 and WebAssembly/Canvas frontends can drive the same game
+// loop without either depending on the other's windowing library.
+package input
+
+// EventType is the kind of input event a Scene receives
+type EventType int
+
+// Event kinds understood by Scene
+const (
+	KeyDown EventType = iota
+	KeyUp
+	Quit
+)
+
+// Key identifies an abstract key, independent of the originating backend
+type Key int
+
+// Keys recognised by the game
+const (
+	KeyNone Key = iota
+	KeyArrowUp
+	KeyArrowDown
+	KeyArrowLeft
+	KeyArrowRight
+	KeyEnter
+	KeyEscape
+)
+
+// Event is a single input event pumped into a Scene via its event channel
+type Event struct {
+	Type EventType
+	Key  Key
+}
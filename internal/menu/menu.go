@@ -0,0 +1,148 @@
+// Package menu implements a keyboard-navigable launcher built from a config
+// file, so the game's entry screen can be restyled and extended (new menu
+// entries, shell commands) without recompiling.
+package menu
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/3auris/snakery/internal/input"
+	"github.com/3auris/snakery/internal/object"
+	"github.com/3auris/snakery/pkg/grafio"
+)
+
+// Target state names recognised in an entry's target field
+const (
+	TargetPlay     = "play"
+	TargetQuit     = "quit"
+	TargetSettings = "settings"
+)
+
+// entrySize, entryGapY and firstY lay out menu entries vertically, centered horizontally
+const (
+	entrySize = 24
+	entryGapY = 0.1
+	firstY    = 0.3
+)
+
+// Entry is one selectable line in a Menu, as loaded from a menu config file
+type Entry struct {
+	Label   string `yaml:"label"`
+	Target  string `yaml:"target"`
+	Command string `yaml:"command,omitempty"`
+}
+
+// config is the shape of a menu config file
+type config struct {
+	Entries []Entry `yaml:"entries"`
+}
+
+// Menu is a data-driven launcher: a list of entries navigated with up/down
+// and activated with enter, each pointing at a game state or a shell command
+type Menu struct {
+	entries  []Entry
+	selected int
+	next     object.GameState
+}
+
+// Load reads a menu config file. A missing file is not an error: it returns
+// a nil Menu so the caller can fall back to a default screen.
+func Load(path string) (*Menu, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("could not read menu file: %v", err)
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not parse menu file: %v", err)
+	}
+
+	if len(cfg.Entries) == 0 {
+		return nil, fmt.Errorf("menu file %s has no entries", path)
+	}
+
+	return &Menu{entries: cfg.Entries, next: object.MenuScreen}, nil
+}
+
+// HandleEvent moves the selection up/down, or activates the selected entry on enter
+func (m *Menu) HandleEvent(e input.Event) {
+	if e.Type != input.KeyDown {
+		return
+	}
+
+	switch e.Key {
+	case input.KeyArrowUp:
+		m.selected = (m.selected - 1 + len(m.entries)) % len(m.entries)
+	case input.KeyArrowDown:
+		m.selected = (m.selected + 1) % len(m.entries)
+	case input.KeyEnter:
+		m.activate(m.entries[m.selected])
+	}
+}
+
+// activate runs an entry's shell command, if any, and resolves its target state
+func (m *Menu) activate(entry Entry) {
+	if entry.Command != "" {
+		if err := exec.Command("sh", "-c", entry.Command).Start(); err != nil {
+			fmt.Fprintf(os.Stderr, "could not run menu command %q: %v\n", entry.Command, err)
+		}
+	}
+
+	switch entry.Target {
+	case TargetPlay:
+		m.next = object.SnakeRunning
+	case TargetQuit:
+		os.Exit(0)
+	case TargetSettings:
+		m.next = object.MenuScreen
+	}
+}
+
+// Update returns the state the last activated entry requested. The request is
+// one-shot: once consumed it resets to MenuScreen, so a later return trip to
+// the menu (e.g. after a death/respawn flow) doesn't instantly replay a stale
+// transition without a fresh Enter press.
+func (m *Menu) Update() object.GameState {
+	next := m.next
+	m.next = object.MenuScreen
+
+	return next
+}
+
+// Paint draws each entry, highlighting the currently selected one
+func (m *Menu) Paint(d grafio.Drawer) error {
+	for i, entry := range m.entries {
+		color := d.TextColor()
+		if i == m.selected {
+			color = d.SnakeColor()
+		}
+
+		opts := grafio.TextOpts{
+			Size:  entrySize,
+			XCof:  0.5,
+			YCof:  firstY + float32(i)*entryGapY,
+			Color: color,
+		}
+
+		w, _, err := d.MeasureText(entry.Label, opts)
+		if err != nil {
+			return fmt.Errorf("could not measure menu entry %q: %v", entry.Label, err)
+		}
+		opts.XCof -= float32(w) / float32(2*d.ScreenWidth())
+
+		if err := d.Text(entry.Label, opts); err != nil {
+			return fmt.Errorf("could not paint menu entry %q: %v", entry.Label, err)
+		}
+	}
+
+	return nil
+}
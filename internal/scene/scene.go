@@ -1,35 +1,105 @@
 package scene
 
 import (
+	"encoding/json"
 	"os"
 	"time"
 
 	"github.com/pkg/errors"
-	"github.com/veandco/go-sdl2/sdl"
 
+	"github.com/3auris/snakery/internal/input"
+	"github.com/3auris/snakery/internal/menu"
 	"github.com/3auris/snakery/internal/object"
 	"github.com/3auris/snakery/pkg/grafio"
 )
 
+// defaultThemePath is where New looks for an optional theme.json next to the binary
+const defaultThemePath = "theme.json"
+
+// defaultMenuPath is where New looks for an optional, data-driven menu config
+const defaultMenuPath = "res/menu.yml"
+
+// defaultTick is used when no theme (or no tick interval) is provided
+const defaultTick = 55 * time.Millisecond
+
+// Theme describes the colors, font and tick speed a scene is painted with,
+// so the game can be restyled by dropping a theme.json next to the binary
+// instead of recompiling.
+type Theme struct {
+	Background *grafio.RGBA `json:"background"`
+	Snake      *grafio.RGBA `json:"snake"`
+	Apple      *grafio.RGBA `json:"apple"`
+	Text       *grafio.RGBA `json:"text"`
+	Font       string       `json:"font"`
+	TickMs     int          `json:"tick_interval_ms"`
+}
+
+// loadTheme reads and decodes a Theme from path. A missing file is not an
+// error: it simply means no theme is applied.
+func loadTheme(path string) (*Theme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "could not open theme file")
+	}
+	defer f.Close()
+
+	var t Theme
+	if err := json.NewDecoder(f).Decode(&t); err != nil {
+		return nil, errors.Wrap(err, "could not decode theme file")
+	}
+
+	return &t, nil
+}
+
+// tick returns the theme's configured tick interval, or defaultTick if unset
+func (t *Theme) tick() time.Duration {
+	if t == nil || t.TickMs == 0 {
+		return defaultTick
+	}
+
+	return time.Duration(t.TickMs) * time.Millisecond
+}
+
 // Scene holds paints and state of the current game
 type Scene struct {
-	r *sdl.Renderer
-	w *sdl.Window
-
 	drawer grafio.Drawer
 	state  object.GameState
 	paints map[object.GameState][]object.Paintable
+
+	tick time.Duration
 }
 
 // New create new Scene with given parameters
 func New(d grafio.Drawer) (*Scene, error) {
+	theme, err := loadTheme(defaultThemePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load theme")
+	}
+
+	if err := applyTheme(d, theme); err != nil {
+		return nil, errors.Wrap(err, "could not apply theme")
+	}
+
 	scrn := object.GameScreen{W: d.ScreenWidth(), H: d.ScreenHeight()}
 
 	apple := object.NewApple()
 	score := object.NewScore()
 	snake := object.NewSnake(apple, score, scrn)
 	deadScreen := &object.DeadScreen{Score: score, Screen: scrn}
-	menuScreen := &object.WelcomeText{Screen: scrn, Snake: snake}
+
+	m, err := menu.Load(defaultMenuPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load menu")
+	}
+
+	var menuScreen object.Paintable = &object.WelcomeText{Screen: scrn, Snake: snake}
+	if m != nil {
+		menuScreen = m
+	}
 
 	return &Scene{
 		drawer: d,
@@ -40,15 +110,58 @@ func New(d grafio.Drawer) (*Scene, error) {
 			object.SnakeRunning: {snake, apple, score},
 			object.DeadSnake:    {deadScreen},
 		},
+
+		tick: theme.tick(),
 	}, nil
 }
 
+// applyTheme pushes the theme's colors and font onto the drawer. A nil theme
+// (no theme.json present) leaves the drawer's defaults untouched, and so does
+// any individual field the theme omits.
+func applyTheme(d grafio.Drawer, theme *Theme) error {
+	if theme == nil {
+		return nil
+	}
+
+	if theme.Background != nil {
+		if err := d.SetBackground(*theme.Background); err != nil {
+			return errors.Wrap(err, "could not set background")
+		}
+	}
+
+	if theme.Snake != nil {
+		if err := d.SetSnakeColor(*theme.Snake); err != nil {
+			return errors.Wrap(err, "could not set snake color")
+		}
+	}
+
+	if theme.Apple != nil {
+		if err := d.SetAppleColor(*theme.Apple); err != nil {
+			return errors.Wrap(err, "could not set apple color")
+		}
+	}
+
+	if theme.Text != nil {
+		if err := d.SetTextColor(*theme.Text); err != nil {
+			return errors.Wrap(err, "could not set text color")
+		}
+	}
+
+	if theme.Font != "" {
+		if err := d.SetMainFont(theme.Font); err != nil {
+			return errors.Wrap(err, "could not set font")
+		}
+	}
+
+	return nil
+}
+
 // Run runs goroutine and updates all paints and listening of events
-func (s *Scene) Run(events <-chan sdl.Event) <-chan error {
+func (s *Scene) Run(events <-chan input.Event) <-chan error {
 	errc := make(chan error)
 
 	go func() {
-		ticker := time.Tick(55 * time.Millisecond)
+		ticker := time.Tick(s.tick)
 
 		for {
 			select {
@@ -76,21 +189,12 @@ func (s *Scene) Run(events <-chan sdl.Event) <-chan error {
 	return errc
 }
 
-func (s *Scene) handleExit(event sdl.Event) bool {
-	switch ev := event.(type) {
-	case *sdl.QuitEvent:
+func (s *Scene) handleExit(event input.Event) bool {
+	if event.Type == input.Quit {
 		return true
-	case *sdl.KeyboardEvent:
-		if ev.State != sdl.PRESSED {
-			break
-		}
-
-		switch event.(*sdl.KeyboardEvent).Keysym.Sym {
-		case sdl.K_ESCAPE:
-			return true
-		}
 	}
-	return false
+
+	return event.Type == input.KeyDown && event.Key == input.KeyEscape
 }
 
 func (s Scene) update() object.GameState {
@@ -0,0 +1,118 @@
+// Package sprite decodes palette-indexed, multi-frame sprite assets.
+//
+// A sprite file is a small binary format:
+//
+//	uint16 frameCount
+//	frameCount * (uint16 width, uint16 height)
+//	frameCount * (width*height bytes of palette indices)
+//
+// A companion .pal file holds the palette: 256 entries of RGBA (4 bytes
+// each), indexed by the byte values found in the sprite's pixel data.
+package sprite
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// PaletteSize is the fixed number of entries a .pal file holds
+const PaletteSize = 256
+
+// RGBA is a palette entry
+type RGBA struct {
+	R, G, B, A uint8
+}
+
+// Palette maps a pixel index to its RGBA color
+type Palette [PaletteSize]RGBA
+
+// Frame is a single indexed-color frame of a sprite
+type Frame struct {
+	W, H    int32
+	Indices []byte
+}
+
+// Sprite is a sequence of indexed-color frames sharing a palette
+type Sprite struct {
+	Frames []Frame
+}
+
+// Load decodes a sprite file from path
+func Load(path string) (*Sprite, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open sprite file: %v", err)
+	}
+	defer f.Close()
+
+	var frameCount uint16
+	if err := binary.Read(f, binary.LittleEndian, &frameCount); err != nil {
+		return nil, fmt.Errorf("could not read frame count: %v", err)
+	}
+
+	dims := make([][2]uint16, frameCount)
+	for i := range dims {
+		if err := binary.Read(f, binary.LittleEndian, &dims[i]); err != nil {
+			return nil, fmt.Errorf("could not read frame %d dimensions: %v", i, err)
+		}
+	}
+
+	s := &Sprite{Frames: make([]Frame, frameCount)}
+	for i, d := range dims {
+		w, h := int32(d[0]), int32(d[1])
+		indices := make([]byte, w*h)
+
+		if _, err := io.ReadFull(f, indices); err != nil {
+			return nil, fmt.Errorf("could not read frame %d pixels: %v", i, err)
+		}
+
+		s.Frames[i] = Frame{W: w, H: h, Indices: indices}
+	}
+
+	return s, nil
+}
+
+// LoadPalette decodes a .pal file from path
+func LoadPalette(path string) (Palette, error) {
+	var pal Palette
+
+	f, err := os.Open(path)
+	if err != nil {
+		return pal, fmt.Errorf("could not open palette file: %v", err)
+	}
+	defer f.Close()
+
+	for i := 0; i < PaletteSize; i++ {
+		var entry [4]byte
+		if _, err := io.ReadFull(f, entry[:]); err != nil {
+			return pal, fmt.Errorf("could not read palette entry %d: %v", i, err)
+		}
+
+		pal[i] = RGBA{R: entry[0], G: entry[1], B: entry[2], A: entry[3]}
+	}
+
+	return pal, nil
+}
+
+// Frame returns the frame at the given index
+func (s Sprite) Frame(frame int) (Frame, error) {
+	if frame < 0 || frame >= len(s.Frames) {
+		return Frame{}, fmt.Errorf("frame %d out of range [0,%d)", frame, len(s.Frames))
+	}
+
+	return s.Frames[frame], nil
+}
+
+// RGBA renders the frame's indexed pixels through pal into a flat RGBA buffer
+func (f Frame) RGBA(pal Palette) []byte {
+	out := make([]byte, 0, len(f.Indices)*4)
+
+	for _, idx := range f.Indices {
+		c := pal[idx]
+		out = append(out, c.R, c.G, c.B, c.A)
+	}
+
+	return out
+}
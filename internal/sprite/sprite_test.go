@@ -0,0 +1,157 @@
+package sprite
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSprite builds a minimal sprite file with the given frames and returns its path
+func writeSprite(t *testing.T, frames [][]byte, dims [][2]uint16) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "sprite.bin")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("could not create sprite file: %v", err)
+	}
+	defer f.Close()
+
+	if err := binary.Write(f, binary.LittleEndian, uint16(len(frames))); err != nil {
+		t.Fatalf("could not write frame count: %v", err)
+	}
+
+	for _, d := range dims {
+		if err := binary.Write(f, binary.LittleEndian, d); err != nil {
+			t.Fatalf("could not write frame dimensions: %v", err)
+		}
+	}
+
+	for _, indices := range frames {
+		if _, err := f.Write(indices); err != nil {
+			t.Fatalf("could not write frame pixels: %v", err)
+		}
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dims := [][2]uint16{{2, 2}, {1, 3}}
+	frames := [][]byte{
+		{0, 1, 2, 3},
+		{4, 5, 6},
+	}
+
+	path := writeSprite(t, frames, dims)
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned unexpected error: %v", err)
+	}
+
+	if len(s.Frames) != 2 {
+		t.Fatalf("len(s.Frames) = %d, want 2", len(s.Frames))
+	}
+
+	if s.Frames[0].W != 2 || s.Frames[0].H != 2 {
+		t.Fatalf("frame 0 dims = (%d,%d), want (2,2)", s.Frames[0].W, s.Frames[0].H)
+	}
+
+	if s.Frames[1].W != 1 || s.Frames[1].H != 3 {
+		t.Fatalf("frame 1 dims = (%d,%d), want (1,3)", s.Frames[1].W, s.Frames[1].H)
+	}
+}
+
+func TestLoadTruncatedFile(t *testing.T) {
+	dims := [][2]uint16{{2, 2}}
+	path := writeSprite(t, [][]byte{{0, 1}}, dims) // declares 4 pixels, writes only 2
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load on truncated file = nil error, want error")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.bin")); err == nil {
+		t.Fatal("Load on missing file = nil error, want error")
+	}
+}
+
+func TestFrame(t *testing.T) {
+	path := writeSprite(t, [][]byte{{0, 1, 2, 3}}, [][2]uint16{{2, 2}})
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned unexpected error: %v", err)
+	}
+
+	if _, err := s.Frame(0); err != nil {
+		t.Fatalf("Frame(0) returned unexpected error: %v", err)
+	}
+
+	for _, frame := range []int{-1, 1} {
+		if _, err := s.Frame(frame); err == nil {
+			t.Fatalf("Frame(%d) = nil error, want out of range error", frame)
+		}
+	}
+}
+
+func TestLoadPalette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sprite.pal")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("could not create palette file: %v", err)
+	}
+
+	for i := 0; i < PaletteSize; i++ {
+		if _, err := f.Write([]byte{byte(i), byte(i), byte(i), 255}); err != nil {
+			t.Fatalf("could not write palette entry: %v", err)
+		}
+	}
+	f.Close()
+
+	pal, err := LoadPalette(path)
+	if err != nil {
+		t.Fatalf("LoadPalette returned unexpected error: %v", err)
+	}
+
+	if pal[42] != (RGBA{R: 42, G: 42, B: 42, A: 255}) {
+		t.Fatalf("pal[42] = %+v, want {42,42,42,255}", pal[42])
+	}
+}
+
+func TestLoadPaletteTruncated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "short.pal")
+	if err := os.WriteFile(path, []byte{1, 2, 3, 4}, 0o644); err != nil {
+		t.Fatalf("could not write palette file: %v", err)
+	}
+
+	if _, err := LoadPalette(path); err == nil {
+		t.Fatal("LoadPalette on truncated file = nil error, want error")
+	}
+}
+
+func TestFrameRGBA(t *testing.T) {
+	var pal Palette
+	pal[1] = RGBA{R: 10, G: 20, B: 30, A: 40}
+	pal[2] = RGBA{R: 50, G: 60, B: 70, A: 80}
+
+	f := Frame{W: 2, H: 1, Indices: []byte{1, 2}}
+
+	got := f.RGBA(pal)
+	want := []byte{10, 20, 30, 40, 50, 60, 70, 80}
+
+	if len(got) != len(want) {
+		t.Fatalf("len(RGBA()) = %d, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("RGBA()[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
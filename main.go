@@ -1,3 +1,5 @@
+//go:build !js
+
 package main
 
 import (
@@ -8,11 +10,45 @@ import (
 	"github.com/pkg/errors"
 	"github.com/veandco/go-sdl2/sdl"
 
+	"github.com/3auris/snakery/internal/input"
 	"github.com/3auris/snakery/internal/object"
 	"github.com/3auris/snakery/internal/scene"
 	"github.com/3auris/snakery/pkg/grafio"
 )
 
+// sdlKeys maps sdl keycodes to the backend-agnostic keys the game understands
+var sdlKeys = map[sdl.Keycode]input.Key{
+	sdl.K_UP:     input.KeyArrowUp,
+	sdl.K_DOWN:   input.KeyArrowDown,
+	sdl.K_LEFT:   input.KeyArrowLeft,
+	sdl.K_RIGHT:  input.KeyArrowRight,
+	sdl.K_RETURN: input.KeyEnter,
+	sdl.K_ESCAPE: input.KeyEscape,
+}
+
+// translate converts an sdl.Event into the game's backend-agnostic input.Event,
+// ignoring events the game has no use for
+func translate(e sdl.Event) (input.Event, bool) {
+	switch ev := e.(type) {
+	case *sdl.QuitEvent:
+		return input.Event{Type: input.Quit}, true
+	case *sdl.KeyboardEvent:
+		key, ok := sdlKeys[ev.Keysym.Sym]
+		if !ok {
+			return input.Event{}, false
+		}
+
+		typ := input.KeyDown
+		if ev.State == sdl.RELEASED {
+			typ = input.KeyUp
+		}
+
+		return input.Event{Type: typ, Key: key}, true
+	default:
+		return input.Event{}, false
+	}
+}
+
 func main() {
 	if err := run(); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%v", err)
@@ -43,13 +79,18 @@ func run() (erro error) {
 		return fmt.Errorf("could not create scene: %v", err)
 	}
 
-	events := make(chan sdl.Event)
+	events := make(chan input.Event)
 	errc := s.Run(events)
 
 	runtime.LockOSThread()
 	for {
+		ev, ok := translate(sdl.WaitEvent())
+		if !ok {
+			continue
+		}
+
 		select {
-		case events <- sdl.WaitEvent():
+		case events <- ev:
 		case err := <-errc:
 			return err
 		}
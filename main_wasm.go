@@ -0,0 +1,80 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/3auris/snakery/internal/input"
+	"github.com/3auris/snakery/internal/object"
+	"github.com/3auris/snakery/internal/scene"
+	"github.com/3auris/snakery/pkg/grafio"
+)
+
+// browserKeys maps browser KeyboardEvent.key values to the game's keys
+var browserKeys = map[string]input.Key{
+	"ArrowUp":    input.KeyArrowUp,
+	"ArrowDown":  input.KeyArrowDown,
+	"ArrowLeft":  input.KeyArrowLeft,
+	"ArrowRight": input.KeyArrowRight,
+	"Enter":      input.KeyEnter,
+	"Escape":     input.KeyEscape,
+}
+
+func main() {
+	if err := runWasm(); err != nil {
+		js.Global().Get("console").Call("error", err.Error())
+	}
+
+	select {}
+}
+
+func runWasm() error {
+	drawer, destroy, err := grafio.NewCanvasDraw("snakery", object.FontUbuntu, 500, 500)
+	if err != nil {
+		return fmt.Errorf("could not create canvas drawer: %v", err)
+	}
+	defer destroy()
+
+	free, err := drawer.LoadResources("res/fonts", "res/textures")
+	if err != nil {
+		return fmt.Errorf("could not load resources: %v", err)
+	}
+	defer free()
+
+	s, err := scene.New(drawer)
+	if err != nil {
+		return fmt.Errorf("could not create scene: %v", err)
+	}
+
+	events := make(chan input.Event)
+	errc := s.Run(events)
+
+	js.Global().Get("document").Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		pumpKeyEvent(events, args[0], input.KeyDown)
+		return nil
+	}))
+
+	js.Global().Get("document").Call("addEventListener", "keyup", js.FuncOf(func(this js.Value, args []js.Value) any {
+		pumpKeyEvent(events, args[0], input.KeyUp)
+		return nil
+	}))
+
+	go func() {
+		for err := range errc {
+			js.Global().Get("console").Call("error", err.Error())
+		}
+	}()
+
+	return nil
+}
+
+func pumpKeyEvent(events chan<- input.Event, jsEvent js.Value, typ input.EventType) {
+	key, ok := browserKeys[jsEvent.Get("key").String()]
+	if !ok {
+		return
+	}
+
+	events <- input.Event{Type: typ, Key: key}
+}
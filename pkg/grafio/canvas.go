@@ -0,0 +1,345 @@
+//go:build js && wasm
+
+package grafio
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// CanvasDraw adapter that draws into an HTML5 Canvas 2D context via syscall/js
+type CanvasDraw struct {
+	canvas js.Value
+	ctx    js.Value
+
+	fonts     map[string]string
+	fontsPath string
+	textures  map[string]js.Value
+
+	mainFont   string
+	background RGBA
+
+	snakeColor RGBA
+	appleColor RGBA
+	textColor  RGBA
+
+	calls []func()
+
+	w, h int32
+}
+
+// NewCanvasDraw creates a new CanvasDraw bound to the canvas with the given element id
+func NewCanvasDraw(elementID, font string, w, h int32) (*CanvasDraw, func() error, error) {
+	doc := js.Global().Get("document")
+	canvas := doc.Call("getElementById", elementID)
+	if canvas.IsNull() || canvas.IsUndefined() {
+		return nil, nil, fmt.Errorf("could not find canvas element %q", elementID)
+	}
+
+	canvas.Set("width", w)
+	canvas.Set("height", h)
+
+	ctx := canvas.Call("getContext", "2d")
+	if ctx.IsNull() || ctx.IsUndefined() {
+		return nil, nil, fmt.Errorf("could not get 2d context of canvas %q", elementID)
+	}
+
+	c := &CanvasDraw{
+		canvas: canvas,
+		ctx:    ctx,
+
+		mainFont:   font,
+		background: ColorWhite,
+
+		snakeColor: ColorGreen,
+		appleColor: ColorRed,
+		textColor:  ColorBlack,
+
+		fonts:    map[string]string{},
+		textures: map[string]js.Value{},
+
+		w: w,
+		h: h,
+	}
+
+	return c, func() error { return nil }, nil
+}
+
+// ScreenHeight returns the height in pixels of the canvas
+func (c CanvasDraw) ScreenHeight() int32 {
+	return c.h
+}
+
+// ScreenWidth returns the width in pixels of the canvas
+func (c CanvasDraw) ScreenWidth() int32 {
+	return c.w
+}
+
+// SetMainFont sets the default font used for all text, identified by family
+// name. If the family hasn't been fetched yet, it is loaded lazily from
+// fontsPath (as passed to LoadResources) via the browser's FontFace API.
+func (c *CanvasDraw) SetMainFont(fontFileName string) error {
+	if _, ok := c.fonts[fontFileName]; ok {
+		c.mainFont = fontFileName
+
+		return nil
+	}
+
+	if err := c.loadFont(fontFileName); err != nil {
+		return fmt.Errorf("could not load font %s: %v", fontFileName, err)
+	}
+
+	c.mainFont = fontFileName
+
+	return nil
+}
+
+// loadFont fetches and registers a font family via the browser's FontFace API
+func (c *CanvasDraw) loadFont(name string) error {
+	url := c.fontsPath + "/" + name + ".ttf"
+
+	done := make(chan error, 1)
+	face := js.Global().Get("FontFace").New(name, fmt.Sprintf("url(%s)", url))
+	face.Call("load").Call("then",
+		js.FuncOf(func(this js.Value, args []js.Value) any {
+			js.Global().Get("document").Get("fonts").Call("add", face)
+			done <- nil
+			return nil
+		}),
+		js.FuncOf(func(this js.Value, args []js.Value) any {
+			done <- fmt.Errorf("could not load font %s", name)
+			return nil
+		}),
+	)
+
+	if err := <-done; err != nil {
+		return err
+	}
+
+	c.fonts[name] = name
+
+	return nil
+}
+
+// Background draws the whole background of the canvas to the given color
+func (c *CanvasDraw) Background(rgba RGBA) error {
+	c.calls = append(c.calls, func() {
+		c.ctx.Set("fillStyle", cssColor(rgba))
+		c.ctx.Call("fillRect", 0, 0, c.w, c.h)
+	})
+
+	return nil
+}
+
+// SetBackground sets the color painted by Present before the scene is drawn
+func (c *CanvasDraw) SetBackground(rgba RGBA) error {
+	c.background = rgba
+
+	return nil
+}
+
+// SnakeColor returns the color paints should use for the snake
+func (c *CanvasDraw) SnakeColor() RGBA {
+	return c.snakeColor
+}
+
+// SetSnakeColor overrides the color returned by SnakeColor
+func (c *CanvasDraw) SetSnakeColor(rgba RGBA) error {
+	c.snakeColor = rgba
+
+	return nil
+}
+
+// AppleColor returns the color paints should use for the apple
+func (c *CanvasDraw) AppleColor() RGBA {
+	return c.appleColor
+}
+
+// SetAppleColor overrides the color returned by AppleColor
+func (c *CanvasDraw) SetAppleColor(rgba RGBA) error {
+	c.appleColor = rgba
+
+	return nil
+}
+
+// TextColor returns the default color paints should use for text
+func (c *CanvasDraw) TextColor() RGBA {
+	return c.textColor
+}
+
+// SetTextColor overrides the color returned by TextColor
+func (c *CanvasDraw) SetTextColor(rgba RGBA) error {
+	c.textColor = rgba
+
+	return nil
+}
+
+// ColorRect draws a filled rectangle in the given coordinates
+func (c *CanvasDraw) ColorRect(x, y, w, h int32, rgba RGBA) error {
+	c.calls = append(c.calls, func() {
+		c.ctx.Set("fillStyle", cssColor(rgba))
+		c.ctx.Call("fillRect", x, y, w, h)
+	})
+
+	return nil
+}
+
+// TextureRect draws a texture rectangle in the given coordinates
+func (c *CanvasDraw) TextureRect(x, y, w, h int32, texture string) error {
+	img, ok := c.textures[texture]
+	if !ok {
+		return fmt.Errorf("texture %s is not found", texture)
+	}
+
+	c.calls = append(c.calls, func() {
+		c.ctx.Call("drawImage", img, x, y, w, h)
+	})
+
+	return nil
+}
+
+// Text draws given text with options to the canvas
+func (c *CanvasDraw) Text(txt string, opts TextOpts) error {
+	font, ok := c.fonts[c.mainFont]
+	if !ok {
+		return fmt.Errorf("font %s is not loaded", c.mainFont)
+	}
+
+	c.calls = append(c.calls, func() {
+		c.ctx.Set("fillStyle", cssColor(opts.Color))
+		c.ctx.Set("font", fmt.Sprintf("%dpx %s", opts.Size, font))
+		c.ctx.Set("textAlign", textAlign(opts.Align))
+		c.ctx.Call("fillText", txt, sizeCal(c.w, opts.XCof), sizeCal(c.h, opts.YCof))
+	})
+
+	return nil
+}
+
+// MeasureText returns the pixel width and height txt would occupy if drawn with opts
+func (c *CanvasDraw) MeasureText(txt string, opts TextOpts) (w, h int32, erro error) {
+	font, ok := c.fonts[c.mainFont]
+	if !ok {
+		return 0, 0, fmt.Errorf("font %s is not loaded", c.mainFont)
+	}
+
+	c.ctx.Set("font", fmt.Sprintf("%dpx %s", opts.Size, font))
+	metrics := c.ctx.Call("measureText", txt)
+
+	return int32(metrics.Get("width").Float()), opts.Size, nil
+}
+
+// Sprite is not supported by CanvasDraw: it draws nothing and always returns an error
+func (c *CanvasDraw) Sprite(name string, frame int, x, y, w, h int32) error {
+	return fmt.Errorf("sprite rendering is not yet supported by CanvasDraw")
+}
+
+// Present flushes the buffered draw calls to the canvas
+func (c *CanvasDraw) Present(f func() error) error {
+	c.calls = c.calls[:0]
+
+	if err := c.Background(c.background); err != nil {
+		return fmt.Errorf("could not set the background: %v", err)
+	}
+
+	if err := f(); err != nil {
+		return fmt.Errorf("could not execute user given function: %v", err)
+	}
+
+	for _, call := range c.calls {
+		call()
+	}
+
+	return nil
+}
+
+// LoadResources fetches the main font and textures from the given URL prefixes
+func (c *CanvasDraw) LoadResources(fontsPath, texturesPath string) (func() error, error) {
+	c.fontsPath = fontsPath
+
+	if err := c.loadFont(c.mainFont); err != nil {
+		return nil, fmt.Errorf("could not load main font: %v", err)
+	}
+
+	imageCtor := js.Global().Get("Image")
+
+	textures, err := fetchManifest(texturesPath + "/manifest.json")
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch texture manifest: %v", err)
+	}
+
+	for _, name := range textures {
+		url := texturesPath + "/" + name
+
+		done := make(chan error, 1)
+		img := imageCtor.New()
+		img.Call("addEventListener", "load", js.FuncOf(func(this js.Value, args []js.Value) any {
+			done <- nil
+			return nil
+		}))
+		img.Call("addEventListener", "error", js.FuncOf(func(this js.Value, args []js.Value) any {
+			done <- fmt.Errorf("could not load texture %s", name)
+			return nil
+		}))
+		img.Set("src", url)
+
+		if err := <-done; err != nil {
+			return nil, err
+		}
+
+		c.textures[name] = img
+	}
+
+	return func() error { return nil }, nil
+}
+
+// fetchManifest fetches a JSON array of texture file names from url, e.g.
+// ["snake.png","apple.png"], since the browser has no directory listing
+// equivalent to ioutil.ReadDir
+func fetchManifest(url string) ([]string, error) {
+	type result struct {
+		names []string
+		err   error
+	}
+	done := make(chan result, 1)
+
+	js.Global().Call("fetch", url).Call("then",
+		js.FuncOf(func(this js.Value, args []js.Value) any {
+			args[0].Call("json").Call("then",
+				js.FuncOf(func(this js.Value, args []js.Value) any {
+					names := make([]string, args[0].Length())
+					for i := range names {
+						names[i] = args[0].Index(i).String()
+					}
+
+					done <- result{names: names}
+					return nil
+				}),
+				js.FuncOf(func(this js.Value, args []js.Value) any {
+					done <- result{err: fmt.Errorf("could not decode texture manifest")}
+					return nil
+				}),
+			)
+			return nil
+		}),
+		js.FuncOf(func(this js.Value, args []js.Value) any {
+			done <- result{err: fmt.Errorf("could not fetch %s", url)}
+			return nil
+		}),
+	)
+
+	r := <-done
+
+	return r.names, r.err
+}
+
+func cssColor(rgba RGBA) string {
+	return fmt.Sprintf("rgba(%d,%d,%d,%.3f)", rgba.R, rgba.G, rgba.B, float64(rgba.A)/255)
+}
+
+func textAlign(align TextAlign) string {
+	if align == Right {
+		return "right"
+	}
+
+	return "left"
+}
@@ -0,0 +1,129 @@
+package grafio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// namedColors maps common color names to their RGBA value. These are
+// intentionally distinct from the legacy ColorBlack/ColorGreen package vars,
+// whose alpha values were tuned for Sdl2Draw (which never sets a blend mode
+// and so ignores alpha entirely) and would render wrong - e.g. fully
+// transparent or near-invisible - on backends like CanvasDraw that do honor it.
+var namedColors = map[string]RGBA{
+	"black":       {R: 0, G: 0, B: 0, A: 255},
+	"white":       ColorWhite,
+	"green":       {R: 34, G: 139, B: 34, A: 255},
+	"red":         {R: 255, G: 0, B: 0, A: 255},
+	"blue":        {R: 0, G: 0, B: 255, A: 255},
+	"yellow":      {R: 255, G: 255, B: 0, A: 255},
+	"gray":        {R: 128, G: 128, B: 128, A: 255},
+	"transparent": {R: 0, G: 0, B: 0, A: 0},
+}
+
+// ParseColor parses a "#RGB", "#RRGGBB" or "#RRGGBBAA" hex string, or a name
+// from the named-color table, into an RGBA. Alpha defaults to 255 when omitted.
+func ParseColor(s string) (RGBA, error) {
+	if !strings.HasPrefix(s, "#") {
+		c, ok := namedColors[strings.ToLower(s)]
+		if !ok {
+			return RGBA{}, fmt.Errorf("unknown color %q", s)
+		}
+
+		return c, nil
+	}
+
+	hex := s[1:]
+
+	switch len(hex) {
+	case 3:
+		r, g, b, err := parseHexChannels(doubleUp(hex))
+		if err != nil {
+			return RGBA{}, err
+		}
+
+		return RGBA{R: r, G: g, B: b, A: 255}, nil
+	case 6:
+		r, g, b, err := parseHexChannels(hex)
+		if err != nil {
+			return RGBA{}, err
+		}
+
+		return RGBA{R: r, G: g, B: b, A: 255}, nil
+	case 8:
+		r, g, b, err := parseHexChannels(hex[:6])
+		if err != nil {
+			return RGBA{}, err
+		}
+
+		a, err := parseHexByte(hex[6:8])
+		if err != nil {
+			return RGBA{}, err
+		}
+
+		return RGBA{R: r, G: g, B: b, A: a}, nil
+	default:
+		return RGBA{}, fmt.Errorf("invalid color %q: expected #RGB, #RRGGBB or #RRGGBBAA", s)
+	}
+}
+
+func parseHexChannels(hex string) (r, g, b uint8, erro error) {
+	r, err := parseHexByte(hex[0:2])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	g, err = parseHexByte(hex[2:4])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	b, err = parseHexByte(hex[4:6])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return r, g, b, nil
+}
+
+func parseHexByte(hex string) (uint8, error) {
+	v, err := strconv.ParseUint(hex, 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hex byte %q: %v", hex, err)
+	}
+
+	return uint8(v), nil
+}
+
+func doubleUp(hex string) string {
+	out := make([]byte, 0, len(hex)*2)
+	for i := 0; i < len(hex); i++ {
+		out = append(out, hex[i], hex[i])
+	}
+
+	return string(out)
+}
+
+// MarshalJSON encodes the color as a "#RRGGBBAA" hex string
+func (rgba RGBA) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("#%02x%02x%02x%02x", rgba.R, rgba.G, rgba.B, rgba.A))
+}
+
+// UnmarshalJSON decodes the color from a hex string or named color, see ParseColor
+func (rgba *RGBA) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("could not unmarshal color: %v", err)
+	}
+
+	c, err := ParseColor(s)
+	if err != nil {
+		return err
+	}
+
+	*rgba = c
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package grafio
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    RGBA
+		wantErr bool
+	}{
+		{name: "3 digit hex", in: "#0f0", want: RGBA{R: 0, G: 255, B: 0, A: 255}},
+		{name: "6 digit hex", in: "#ff0000", want: RGBA{R: 255, G: 0, B: 0, A: 255}},
+		{name: "8 digit hex with alpha", in: "#ff00007f", want: RGBA{R: 255, G: 0, B: 0, A: 0x7f}},
+		{name: "named color", in: "red", want: RGBA{R: 255, G: 0, B: 0, A: 255}},
+		{name: "named color case insensitive", in: "RED", want: RGBA{R: 255, G: 0, B: 0, A: 255}},
+		{name: "unknown name", in: "chartreuse", wantErr: true},
+		{name: "wrong length hex", in: "#ff00", wantErr: true},
+		{name: "invalid hex digits", in: "#zzzzzz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseColor(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseColor(%q) = %v, want error", tt.in, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseColor(%q) returned unexpected error: %v", tt.in, err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("ParseColor(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRGBAJSONRoundTrip(t *testing.T) {
+	in := RGBA{R: 18, G: 52, B: 86, A: 120}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal returned unexpected error: %v", err)
+	}
+
+	var out RGBA
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned unexpected error: %v", err)
+	}
+
+	if out != in {
+		t.Fatalf("round trip = %+v, want %+v", out, in)
+	}
+}
@@ -9,6 +9,9 @@ var (
 
 	// ColorBlack rgba black color
 	ColorBlack = RGBA{R: 0, G: 0, B: 0, A: 0}
+
+	// ColorRed rgba red color
+	ColorRed = RGBA{R: 220, G: 20, B: 60, A: 255}
 )
 
 // RGBA have rgba color values
@@ -41,9 +44,36 @@ type Drawer interface {
 	// Background draws the whole background to the given RGBA color
 	Background(rgba RGBA) error
 
+	// SetBackground sets the color painted by Present before the scene is drawn
+	SetBackground(rgba RGBA) error
+
+	// SnakeColor returns the color paints should use for the snake, so it can
+	// be restyled by a theme instead of recompiling
+	SnakeColor() RGBA
+
+	// SetSnakeColor overrides the color returned by SnakeColor
+	SetSnakeColor(rgba RGBA) error
+
+	// AppleColor returns the color paints should use for the apple, so it can
+	// be restyled by a theme instead of recompiling
+	AppleColor() RGBA
+
+	// SetAppleColor overrides the color returned by AppleColor
+	SetAppleColor(rgba RGBA) error
+
+	// TextColor returns the default color paints should use for text, so it
+	// can be restyled by a theme instead of recompiling
+	TextColor() RGBA
+
+	// SetTextColor overrides the color returned by TextColor
+	SetTextColor(rgba RGBA) error
+
 	// Text writes given text with given options to the window
 	Text(txt string, opts TextOpts) error
 
+	// MeasureText returns the pixel width and height txt would occupy if drawn with opts
+	MeasureText(txt string, opts TextOpts) (w, h int32, err error)
+
 	// ColorRect draw rectangle with the given color
 	ColorRect(x, y, w, h int32, rgba RGBA) error
 
@@ -64,4 +94,7 @@ type Drawer interface {
 
 	// ScreenWidth returns the width of screen in pixels
 	ScreenWidth() int32
+
+	// Sprite draws the given frame of a previously loaded sprite at the given coordinates
+	Sprite(name string, frame int, x, y, w, h int32) error
 }
@@ -0,0 +1,99 @@
+package grafio
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// fontDirs returns the platform-appropriate set of directories to search for
+// installed fonts, in order of preference
+func fontDirs() []string {
+	home := "~"
+	if u, err := user.Current(); err == nil {
+		home = u.HomeDir
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		return []string{`C:\Windows\Fonts`}
+	case "darwin":
+		return []string{
+			filepath.Join(home, "Library", "Fonts"),
+			"/Library/Fonts",
+			"/System/Library/Fonts",
+		}
+	default:
+		return []string{
+			filepath.Join(home, ".fonts"),
+			"/usr/share/fonts",
+			"/usr/local/share/fonts",
+		}
+	}
+}
+
+// FindFont searches the platform's font directories for a <name>.ttf or
+// <name>.otf file, expanding a leading ~ in each directory via os/user, and
+// returns the path to the first match
+func FindFont(name string) (string, error) {
+	for _, dir := range fontDirs() {
+		dir = expandHome(dir)
+
+		for _, ext := range []string{".ttf", ".otf"} {
+			path, err := findInDir(dir, name+ext)
+			if err != nil {
+				return "", err
+			}
+
+			if path != "" {
+				return path, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("could not find font %q in any known font directory", name)
+}
+
+// findInDir walks dir looking for a file matching fileName, case-insensitively
+func findInDir(dir, fileName string) (string, error) {
+	var found string
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// directory may simply not exist on this system, skip it
+			return filepath.SkipDir
+		}
+
+		if found != "" {
+			return filepath.SkipDir
+		}
+
+		if !info.IsDir() && strings.EqualFold(info.Name(), fileName) {
+			found = path
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not walk font directory %s: %v", dir, err)
+	}
+
+	return found, nil
+}
+
+// expandHome replaces a leading ~ with the current user's home directory
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(u.HomeDir, strings.TrimPrefix(path, "~"))
+}
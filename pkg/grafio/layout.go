@@ -0,0 +1,7 @@
+package grafio
+
+// sizeCal scales a screen dimension by a 0-1 coefficient into a pixel
+// position, shared by every Drawer backend
+func sizeCal(size int32, cof float32) int32 {
+	return int32(float32(size) * (float32(cof)))
+}
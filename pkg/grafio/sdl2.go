@@ -1,6 +1,9 @@
+//go:build !js
+
 package grafio
 
 import (
+	"container/list"
 	"fmt"
 	"io/ioutil"
 
@@ -8,16 +11,58 @@ import (
 	"github.com/veandco/go-sdl2/img"
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
+
+	"github.com/3auris/snakery/internal/sprite"
 )
 
+// glyphCacheSize is the number of rendered glyph textures kept around before
+// the least recently used one is evicted
+const glyphCacheSize = 64
+
+// fontKey identifies a (family, size) pair in the opened-font cache
+type fontKey struct {
+	family string
+	size   int32
+}
+
+// glyphKey identifies a single rendered glyph in the glyph cache. Caching per
+// rune, rather than per whole string, means dynamic text that changes every
+// frame (e.g. a score counter) still reuses the textures of the runes it
+// shares with its previous value instead of missing the cache entirely.
+type glyphKey struct {
+	font fontKey
+	r    rune
+	rgba RGBA
+}
+
+// glyphEntry is a single cached rendered glyph texture and its measured pixel size
+type glyphEntry struct {
+	texture *sdl.Texture
+	w, h    int32
+}
+
 // Sdl2Draw adapter for sdl2 go bindings
 type Sdl2Draw struct {
 	r *sdl.Renderer
 
-	fonts    map[string]*ttf.Font
-	textures map[string]*sdl.Texture
+	fontPaths map[string]string // family name -> resolved file path
+	fonts     map[fontKey]*ttf.Font
+	textures  map[string]*sdl.Texture
+
+	glyphs     map[glyphKey]*list.Element
+	glyphOrder *list.List
+
+	sprites        map[string]*sprite.Sprite
+	palettes       map[string]sprite.Palette
+	spriteTextures map[string]*sdl.Texture
 
-	mainFont string
+	mainFont   string
+	fontsPath  string
+	background RGBA
+
+	snakeColor RGBA
+	appleColor RGBA
+	textColor  RGBA
 
 	w, h int32
 }
@@ -30,10 +75,23 @@ func NewSdl2Draw(font string, w, h int32) (sdl2draw *Sdl2Draw, destroy func() er
 	}
 
 	return &Sdl2Draw{
-		mainFont: font,
+		mainFont:   font,
+		background: ColorWhite,
+
+		snakeColor: ColorGreen,
+		appleColor: ColorRed,
+		textColor:  ColorBlack,
+
+		fontPaths: map[string]string{},
+		fonts:     map[fontKey]*ttf.Font{},
+		textures:  map[string]*sdl.Texture{},
 
-		fonts:    map[string]*ttf.Font{},
-		textures: map[string]*sdl.Texture{},
+		glyphs:     map[glyphKey]*list.Element{},
+		glyphOrder: list.New(),
+
+		sprites:        map[string]*sprite.Sprite{},
+		palettes:       map[string]sprite.Palette{},
+		spriteTextures: map[string]*sdl.Texture{},
 
 		r: r,
 		w: w,
@@ -41,17 +99,156 @@ func NewSdl2Draw(font string, w, h int32) (sdl2draw *Sdl2Draw, destroy func() er
 	}, destroy, nil
 }
 
-// SetMainFont sets the default font for all text
+// LoadSprite loads a sprite and its palette so it can be drawn by name via Sprite
+func (s *Sdl2Draw) LoadSprite(name, spritePath, palettePath string) error {
+	spr, err := sprite.Load(spritePath)
+	if err != nil {
+		return errors.Wrap(err, "could not load sprite")
+	}
+
+	pal, err := sprite.LoadPalette(palettePath)
+	if err != nil {
+		return errors.Wrap(err, "could not load palette")
+	}
+
+	s.sprites[name] = spr
+	s.palettes[name] = pal
+
+	return nil
+}
+
+// SetPalette swaps the palette used to render the named sprite, e.g. to recolor
+// it for an effect such as a poisoned apple, without reloading the sprite itself
+func (s *Sdl2Draw) SetPalette(name string, pal sprite.Palette) error {
+	if _, ok := s.sprites[name]; !ok {
+		return fmt.Errorf("sprite %s is not loaded", name)
+	}
+
+	s.palettes[name] = pal
+
+	for key := range s.spriteTextures {
+		if key == name || len(key) > len(name) && key[:len(name)+1] == name+"#" {
+			s.spriteTextures[key].Destroy()
+			delete(s.spriteTextures, key)
+		}
+	}
+
+	return nil
+}
+
+// Sprite draws the given frame of a previously loaded sprite at the given coordinates
+func (s *Sdl2Draw) Sprite(name string, frame int, x, y, w, h int32) error {
+	spr, ok := s.sprites[name]
+	if !ok {
+		return fmt.Errorf("sprite %s is not loaded", name)
+	}
+
+	key := fmt.Sprintf("%s#%d", name, frame)
+
+	texture, ok := s.spriteTextures[key]
+	if !ok {
+		f, err := spr.Frame(frame)
+		if err != nil {
+			return errors.Wrap(err, "could not get sprite frame")
+		}
+
+		pixels := f.RGBA(s.palettes[name])
+
+		rmask, gmask, bmask, amask := rgbaMasks()
+
+		surface, err := sdl.CreateRGBSurfaceFrom(pixels, f.W, f.H, 32, int(f.W*4), rmask, gmask, bmask, amask)
+		if err != nil {
+			return errors.Wrap(err, "could not create surface from indexed pixels")
+		}
+		defer surface.Free()
+
+		texture, err = s.r.CreateTextureFromSurface(surface)
+		if err != nil {
+			return errors.Wrap(err, "could not create texture from surface")
+		}
+
+		s.spriteTextures[key] = texture
+	}
+
+	rect := &sdl.Rect{X: x, Y: y, W: w, H: h}
+	if err := s.r.Copy(texture, nil, rect); err != nil {
+		return errors.Wrap(err, "could not copy sprite texture")
+	}
+
+	return nil
+}
+
+// SetMainFont sets the default font used for all text, identified by family
+// name (e.g. "Ubuntu"). The family's file is resolved once, searching the
+// system font directories via FindFont and falling back to the bundled fonts
+// directory passed to LoadResources; individual sizes are then opened lazily
+// by fontFor as they're requested.
 func (s *Sdl2Draw) SetMainFont(fontFileName string) error {
-	if _, ok := s.fonts[fontFileName]; !ok {
-		return fmt.Errorf("font %s is not loaded", fontFileName)
+	if _, ok := s.fontPaths[fontFileName]; ok {
+		s.mainFont = fontFileName
+
+		return nil
 	}
 
+	path, err := FindFont(fontFileName)
+	if err != nil {
+		path, err = findBundledFont(s.fontsPath, fontFileName)
+		if err != nil {
+			return fmt.Errorf("could not find font %s: %v", fontFileName, err)
+		}
+	}
+
+	s.fontPaths[fontFileName] = path
 	s.mainFont = fontFileName
 
 	return nil
 }
 
+// fontFor returns the ttf.Font for the given family at the given size,
+// opening and caching it on first use
+func (s *Sdl2Draw) fontFor(family string, size int32) (*ttf.Font, error) {
+	key := fontKey{family: family, size: size}
+
+	if font, ok := s.fonts[key]; ok {
+		return font, nil
+	}
+
+	path, ok := s.fontPaths[family]
+	if !ok {
+		return nil, fmt.Errorf("font %s is not loaded", family)
+	}
+
+	font, err := ttf.OpenFont(path, int(size))
+	if err != nil {
+		return nil, fmt.Errorf("could not open font %s at size %d: %v", path, size, err)
+	}
+
+	s.fonts[key] = font
+
+	return font, nil
+}
+
+// findBundledFont looks for a <name>.ttf or <name>.otf in the bundled fonts
+// directory, used as a last resort when the family isn't installed on the system
+func findBundledFont(fontsPath, name string) (string, error) {
+	if fontsPath == "" {
+		return "", fmt.Errorf("no bundled fonts directory configured")
+	}
+
+	for _, ext := range []string{".ttf", ".otf"} {
+		path, err := findInDir(fontsPath, name+ext)
+		if err != nil {
+			return "", err
+		}
+
+		if path != "" {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("font %s not found in %s", name, fontsPath)
+}
+
 // ColorRect draws into sdl2 window given color rectangle in the given coordinates
 func (s *Sdl2Draw) ColorRect(x, y, w, h int32, rgba RGBA) error {
 	if err := s.r.SetDrawColor(rgba.R, rgba.G, rgba.B, rgba.A); err != nil {
@@ -102,52 +299,169 @@ func (s *Sdl2Draw) Background(rgba RGBA) error {
 	return nil
 }
 
-// Text draws given text with options to the sdl2 window
-func (s *Sdl2Draw) Text(txt string, opts TextOpts) (erro error) {
+// Text draws given text with options to the sdl2 window, compositing it from
+// individually cached glyph textures
+func (s *Sdl2Draw) Text(txt string, opts TextOpts) error {
+	entries := make([]glyphEntry, 0, len(txt))
+
+	width, height := int32(0), int32(0)
+	for _, r := range txt {
+		entry, err := s.glyphTexture(r, opts)
+		if err != nil {
+			return errors.Wrap(err, "could not get glyph texture")
+		}
+
+		entries = append(entries, entry)
+		width += entry.w
+		if entry.h > height {
+			height = entry.h
+		}
+	}
+
+	x := sizeCal(s.w, opts.XCof)
+	if opts.Align == Right {
+		x -= width
+	}
+	y := sizeCal(s.h, opts.YCof)
+
+	for _, entry := range entries {
+		rect := &sdl.Rect{X: x, Y: y, W: entry.w, H: entry.h}
+
+		if err := s.r.Copy(entry.texture, nil, rect); err != nil {
+			return errors.Wrap(err, "could not copy texture")
+		}
+
+		x += entry.w
+	}
+
+	return nil
+}
+
+// MeasureText returns the pixel width and height txt would occupy if drawn
+// with the given options, using the font's real glyph metrics
+func (s *Sdl2Draw) MeasureText(txt string, opts TextOpts) (w, h int32, erro error) {
+	font, err := s.fontFor(s.mainFont, opts.Size)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not get font")
+	}
+
+	// Text places each rune's glyph right after the previous one, so measure
+	// rune by rune to match it rather than the kerned width of the whole string
+	for _, r := range txt {
+		rw, rh, err := font.SizeUTF8(string(r))
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "could not measure text")
+		}
+
+		w += int32(rw)
+		if int32(rh) > h {
+			h = int32(rh)
+		}
+	}
+
+	return w, h, nil
+}
+
+// glyphTexture returns the cached texture rendering the single rune r with
+// opts, rendering and caching it on first use, and evicting the least
+// recently used entry once the cache grows past glyphCacheSize
+func (s *Sdl2Draw) glyphTexture(r rune, opts TextOpts) (glyphEntry, error) {
+	key := glyphKey{font: fontKey{family: s.mainFont, size: opts.Size}, r: r, rgba: opts.Color}
+
+	if elem, ok := s.glyphs[key]; ok {
+		s.glyphOrder.MoveToFront(elem)
+
+		return elem.Value.(glyphEntry), nil
+	}
+
+	font, err := s.fontFor(s.mainFont, opts.Size)
+	if err != nil {
+		return glyphEntry{}, errors.Wrap(err, "could not get font")
+	}
+
 	c := sdl.Color{R: opts.Color.R, G: opts.Color.G, B: opts.Color.B, A: opts.Color.A}
-	surface, err := s.fonts[s.mainFont].RenderUTF8Solid(txt, c)
+	surface, err := font.RenderUTF8Solid(string(r), c)
 	if err != nil {
-		return errors.Wrap(err, "could not render title")
+		return glyphEntry{}, errors.Wrap(err, "could not render glyph")
 	}
 	defer surface.Free()
 
 	texture, err := s.r.CreateTextureFromSurface(surface)
 	if err != nil {
-		return errors.Wrap(err, "could not create texture")
+		return glyphEntry{}, errors.Wrap(err, "could not create texture")
 	}
 
-	defer func() {
-		if err = texture.Destroy(); err != nil {
-			erro = errors.Wrap(err, "could not destroy texture")
-		}
-	}()
+	entry := glyphEntry{texture: texture, w: surface.W, h: surface.H}
 
-	shift := 0
-	if opts.Align == Right && len(txt) > 1 {
-		shift = (len(txt) * int(opts.Size)) - int(opts.Size)
-	}
+	elem := s.glyphOrder.PushFront(entry)
+	s.glyphs[key] = elem
 
-	rect := &sdl.Rect{
-		X: sizeCal(s.w, opts.XCof) - int32(shift),
-		Y: sizeCal(s.h, opts.YCof),
-		W: opts.Size * int32(len(txt)),
-		H: opts.Size + 20,
-	}
+	if s.glyphOrder.Len() > glyphCacheSize {
+		oldest := s.glyphOrder.Back()
+		s.glyphOrder.Remove(oldest)
 
-	if err := s.r.Copy(texture, nil, rect); err != nil {
-		return errors.Wrap(err, "could not copy texture")
+		for k, e := range s.glyphs {
+			if e == oldest {
+				oldest.Value.(glyphEntry).texture.Destroy()
+				delete(s.glyphs, k)
+				break
+			}
+		}
 	}
 
+	return entry, nil
+}
+
+// SetBackground sets the color painted by Present before the scene is drawn
+func (s *Sdl2Draw) SetBackground(rgba RGBA) error {
+	s.background = rgba
+
+	return nil
+}
+
+// SnakeColor returns the color paints should use for the snake
+func (s *Sdl2Draw) SnakeColor() RGBA {
+	return s.snakeColor
+}
+
+// SetSnakeColor overrides the color returned by SnakeColor
+func (s *Sdl2Draw) SetSnakeColor(rgba RGBA) error {
+	s.snakeColor = rgba
+
+	return nil
+}
+
+// AppleColor returns the color paints should use for the apple
+func (s *Sdl2Draw) AppleColor() RGBA {
+	return s.appleColor
+}
+
+// SetAppleColor overrides the color returned by AppleColor
+func (s *Sdl2Draw) SetAppleColor(rgba RGBA) error {
+	s.appleColor = rgba
+
+	return nil
+}
+
+// TextColor returns the default color paints should use for text
+func (s *Sdl2Draw) TextColor() RGBA {
+	return s.textColor
+}
+
+// SetTextColor overrides the color returned by TextColor
+func (s *Sdl2Draw) SetTextColor(rgba RGBA) error {
+	s.textColor = rgba
+
 	return nil
 }
 
-// Present makes white background of the window calls user's function
+// Present clears the window to the background color and calls user's function
 func (s *Sdl2Draw) Present(f func() error) error {
 	if err := s.r.Clear(); err != nil {
 		return errors.Wrap(err, "could not clear the renderer")
 	}
 
-	if err := s.Background(ColorWhite); err != nil {
+	if err := s.Background(s.background); err != nil {
 		return errors.Wrap(err, "could not set the background")
 	}
 
@@ -160,8 +474,11 @@ func (s *Sdl2Draw) Present(f func() error) error {
 	return nil
 }
 
-// LoadResources load resources of fonts and textures given path
+// LoadResources loads textures from texturesPath, and remembers fontsPath as a
+// bundled fallback directory for fonts requested by family name via SetMainFont
 func (s *Sdl2Draw) LoadResources(fontsPath, texturesPath string) (func() error, error) {
+	s.fontsPath = fontsPath
+
 	textures, err := ioutil.ReadDir(texturesPath)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read dir")
@@ -186,18 +503,8 @@ func (s *Sdl2Draw) LoadResources(fontsPath, texturesPath string) (func() error,
 		s.textures[f.Name()] = texture
 	}
 
-	fonts, err := ioutil.ReadDir(fontsPath)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not read dir")
-	}
-
-	for _, f := range fonts {
-		font, err := ttf.OpenFont(fontsPath+"/"+f.Name(), 124)
-		if err != nil {
-			return nil, fmt.Errorf("could not load font: %v", err)
-		}
-
-		s.fonts[f.Name()] = font
+	if err := s.SetMainFont(s.mainFont); err != nil {
+		return nil, errors.Wrap(err, "could not load main font")
 	}
 
 	return func() error { return s.destroy() }, nil
@@ -210,12 +517,30 @@ func (s *Sdl2Draw) destroy() error {
 		}
 	}
 
+	for _, texture := range s.spriteTextures {
+		if err := texture.Destroy(); err != nil {
+			return errors.Wrap(err, "could not destroy sprite texture")
+		}
+	}
+
+	for _, elem := range s.glyphs {
+		if err := elem.Value.(glyphEntry).texture.Destroy(); err != nil {
+			return errors.Wrap(err, "could not destroy glyph texture")
+		}
+	}
+
 	for _, font := range s.fonts {
 		font.Close()
 	}
 	return nil
 }
 
-func sizeCal(size int32, cof float32) int32 {
-	return int32(float32(size) * (float32(cof)))
+// rgbaMasks returns the RGBA bit masks CreateRGBSurfaceFrom needs to interpret
+// a buffer of packed R,G,B,A bytes on the host's native byte order
+func rgbaMasks() (r, g, b, a uint32) {
+	if sdl.BYTEORDER == sdl.BIG_ENDIAN {
+		return 0xff000000, 0x00ff0000, 0x0000ff00, 0x000000ff
+	}
+
+	return 0x000000ff, 0x0000ff00, 0x00ff0000, 0xff000000
 }